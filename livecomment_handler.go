@@ -45,12 +45,31 @@ type LivecommentModelWithUser struct {
 }
 
 type Livecomment struct {
-	ID         int64      `json:"id"`
-	User       User       `json:"user"`
-	Livestream Livestream `json:"livestream"`
-	Comment    string     `json:"comment"`
-	Tip        int64      `json:"tip"`
-	CreatedAt  int64      `json:"created_at"`
+	ID          int64            `json:"id"`
+	User        User             `json:"user"`
+	Livestream  Livestream       `json:"livestream"`
+	Comment     string           `json:"comment"`
+	Tip         int64            `json:"tip"`
+	CreatedAt   int64            `json:"created_at"`
+	Reactions   map[string]int64 `json:"reactions"`
+	MyReactions []string         `json:"my_reactions"`
+}
+
+// GetLivecommentsResponse is returned from getLivecommentsHandler instead of
+// a bare array only when keyset pagination (before/before_id) is in use, so
+// that NextCursor has somewhere to live without breaking clients that still
+// call the endpoint with limit only.
+type GetLivecommentsResponse struct {
+	Comments   []Livecomment `json:"comments"`
+	NextCursor *NextCursor   `json:"next_cursor"`
+}
+
+// NextCursor mirrors the before/before_id query parameters that
+// getLivecommentsHandler actually accepts, so a client can feed it straight
+// back in as the next page's cursor without having to parse anything.
+type NextCursor struct {
+	Before   int64 `json:"before"`
+	BeforeID int64 `json:"before_id"`
 }
 
 type LivecommentReport struct {
@@ -88,6 +107,11 @@ func getLivecommentsHandler(c echo.Context) error {
 		return err
 	}
 
+	// error already checked
+	sess, _ := session.Get(defaultSessionIDKey, c)
+	// existence already checked
+	viewerUserID := sess.Values[defaultUserIDKey].(int64)
+
 	livestreamID, err := strconv.Atoi(c.Param("livestream_id"))
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "livestream_id in path must be integer")
@@ -109,7 +133,7 @@ func getLivecommentsHandler(c echo.Context) error {
 	}
 
 	query := `
-	SELECT 
+	SELECT
 	l.*,
 	u.name, u.display_name, u.description,t.id as theme_id, i.icon_hash
 	FROM livecomments l
@@ -117,10 +141,31 @@ func getLivecommentsHandler(c echo.Context) error {
 	LEFT JOIN themes t ON t.user_id = u.id
 	LEFT JOIN icons i ON i.user_id = u.id
 	WHERE l.livestream_id = ?
-	ORDER BY l.created_at DESC
 	`
+	args := []interface{}{livestreamID}
+
+	// キーセットページニング。before/before_idが両方揃っているときだけ有効
+	// にする（片方だけの指定は無視してlimitのみの従来動作にフォールバック）。
+	usingCursor := false
+	if c.QueryParam("before") != "" && c.QueryParam("before_id") != "" {
+		beforeCreatedAt, err := strconv.ParseInt(c.QueryParam("before"), 10, 64)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "before query parameter must be a unix timestamp")
+		}
+		beforeID, err := strconv.ParseInt(c.QueryParam("before_id"), 10, 64)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "before_id query parameter must be integer")
+		}
+		query += " AND (l.created_at, l.id) < (?, ?)"
+		args = append(args, beforeCreatedAt, beforeID)
+		usingCursor = true
+	}
+
+	query += " ORDER BY l.created_at DESC, l.id DESC"
+
+	limit := 0
 	if c.QueryParam("limit") != "" {
-		limit, err := strconv.Atoi(c.QueryParam("limit"))
+		limit, err = strconv.Atoi(c.QueryParam("limit"))
 		if err != nil {
 			return echo.NewHTTPError(http.StatusBadRequest, "limit query parameter must be integer")
 		}
@@ -128,7 +173,7 @@ func getLivecommentsHandler(c echo.Context) error {
 	}
 
 	livecommentModels := []LivecommentModelWithUser{}
-	err = tx.SelectContext(ctx, &livecommentModels, query, livestreamID)
+	err = tx.SelectContext(ctx, &livecommentModels, query, args...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return c.JSON(http.StatusOK, []*Livecomment{})
 	}
@@ -136,6 +181,15 @@ func getLivecommentsHandler(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get livecomments: "+err.Error())
 	}
 
+	livecommentIDs := make([]int64, len(livecommentModels))
+	for i := range livecommentModels {
+		livecommentIDs[i] = livecommentModels[i].ID
+	}
+	reactionsByComment, myReactionsByComment, err := loadLivecommentReactionsBulk(ctx, tx, livecommentIDs, viewerUserID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get livecomment reactions: "+err.Error())
+	}
+
 	livecomments := make([]Livecomment, len(livecommentModels))
 	for i := range livecommentModels {
 		if !livecommentModels[i].UserIconHash.Valid {
@@ -154,13 +208,24 @@ func getLivecommentsHandler(c echo.Context) error {
 			IconHash: livecommentModels[i].UserIconHash.String,
 		}
 
+		reactions := reactionsByComment[livecommentModels[i].ID]
+		if reactions == nil {
+			reactions = map[string]int64{}
+		}
+		myReactions := myReactionsByComment[livecommentModels[i].ID]
+		if myReactions == nil {
+			myReactions = []string{}
+		}
+
 		livecomment := Livecomment{
-			ID:         livecommentModels[i].ID,
-			User:       commentOwner,
-			Livestream: livestream,
-			Comment:    livecommentModels[i].Comment,
-			Tip:        livecommentModels[i].Tip,
-			CreatedAt:  livecommentModels[i].CreatedAt,
+			ID:          livecommentModels[i].ID,
+			User:        commentOwner,
+			Livestream:  livestream,
+			Comment:     livecommentModels[i].Comment,
+			Tip:         livecommentModels[i].Tip,
+			CreatedAt:   livecommentModels[i].CreatedAt,
+			Reactions:   reactions,
+			MyReactions: myReactions,
 		}
 		if err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, "failed to fil livecomments: "+err.Error())
@@ -173,7 +238,26 @@ func getLivecommentsHandler(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "failed to commit: "+err.Error())
 	}
 
-	return c.JSON(http.StatusOK, livecomments)
+	var nextCursor *NextCursor
+	if limit > 0 && len(livecommentModels) == limit {
+		last := livecommentModels[len(livecommentModels)-1]
+		nextCursor = &NextCursor{Before: last.CreatedAt, BeforeID: last.ID}
+	}
+
+	if !usingCursor {
+		// 既存クライアントがこのエンドポイントを配列として扱っているため、
+		// カーソル未指定のときもボディは今までどおりの形で返す。ページングを
+		// 始めたいクライアントのためにヘッダーでもnext_cursorを渡しておく。
+		if nextCursor != nil {
+			c.Response().Header().Set("X-Next-Cursor", fmt.Sprintf("before=%d&before_id=%d", nextCursor.Before, nextCursor.BeforeID))
+		}
+		return c.JSON(http.StatusOK, livecomments)
+	}
+
+	return c.JSON(http.StatusOK, GetLivecommentsResponse{
+		Comments:   livecomments,
+		NextCursor: nextCursor,
+	})
 }
 
 func getNgwords(c echo.Context) error {
@@ -253,29 +337,15 @@ func postLivecommentHandler(c echo.Context) error {
 		}
 	}
 
-	// スパム判定
-	var ngwords []*NGWord
-	if err := tx.SelectContext(ctx, &ngwords, "SELECT id, user_id, livestream_id, word FROM ng_words WHERE user_id = ? AND livestream_id = ?", livestreamModel.UserID, livestreamModel.ID); err != nil && !errors.Is(err, sql.ErrNoRows) {
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get NG words: "+err.Error())
+	// スパム判定（NGワードはlivestreamごとにAho–Corasick自動機としてキャッシュ済み。
+	// 自動機が組み立てられない場合のみ1回のSQL round-tripにフォールバックする）
+	isSpam, err := globalNGWordIndex.hit(ctx, tx, livestreamModel.UserID, livestreamModel.ID, req.Comment)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to check NG words: "+err.Error())
 	}
-
-	var hitSpam int
-	for _, ngword := range ngwords {
-		query := `
-		SELECT COUNT(*)
-		FROM
-		(SELECT ? AS text) AS texts
-		INNER JOIN
-		(SELECT CONCAT('%', ?, '%')	AS pattern) AS patterns
-		ON texts.text LIKE patterns.pattern;
-		`
-		if err := tx.GetContext(ctx, &hitSpam, query, req.Comment, ngword.Word); err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, "failed to get hitspam: "+err.Error())
-		}
-		c.Logger().Infof("[hitSpam=%d] comment = %s", hitSpam, req.Comment)
-		if hitSpam >= 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, "このコメントがスパム判定されました")
-		}
+	if isSpam {
+		c.Logger().Infof("[hitSpam] comment = %s", req.Comment)
+		return echo.NewHTTPError(http.StatusBadRequest, "このコメントがスパム判定されました")
 	}
 
 	now := time.Now().Unix()
@@ -298,7 +368,7 @@ func postLivecommentHandler(c echo.Context) error {
 	}
 	livecommentModel.ID = livecommentID
 
-	livecomment, err := fillLivecommentResponse(ctx, tx, livecommentModel)
+	livecomment, err := fillLivecommentResponse(ctx, tx, livecommentModel, userID)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "failed to fill livecomment: "+err.Error())
 	}
@@ -307,6 +377,8 @@ func postLivecommentHandler(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "failed to commit: "+err.Error())
 	}
 
+	publishLivecomment(livecomment)
+
 	return c.JSON(http.StatusCreated, livecomment)
 }
 
@@ -384,6 +456,45 @@ func reportLivecommentHandler(c echo.Context) error {
 	return c.JSON(http.StatusCreated, report)
 }
 
+// getLivecommentReportsHandler lists the reports filed against a livestream.
+// Mounted at GET /api/livestream/:livestream_id/report. Unlike
+// reportLivecommentHandler it fills every report in one bulk pass instead of
+// re-fetching the same users/livestreams/livecomments per row.
+func getLivecommentReportsHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if err := verifyUserSession(c); err != nil {
+		return err
+	}
+
+	livestreamID, err := strconv.Atoi(c.Param("livestream_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "livestream_id in path must be integer")
+	}
+
+	tx, err := dbConn.BeginTxx(ctx, nil)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to begin transaction: "+err.Error())
+	}
+	defer tx.Rollback()
+
+	var reportModels []LivecommentReportModel
+	if err := tx.SelectContext(ctx, &reportModels, "SELECT * FROM livecomment_reports WHERE livestream_id = ? ORDER BY created_at DESC", livestreamID); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get livecomment reports: "+err.Error())
+	}
+
+	reports, err := fillLivecommentReportResponseBulk(ctx, tx, reportModels)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to fill livecomment reports: "+err.Error())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to commit: "+err.Error())
+	}
+
+	return c.JSON(http.StatusOK, reports)
+}
+
 // NGワードを登録
 func moderateHandler(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -438,25 +549,29 @@ func moderateHandler(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get last inserted NG word id: "+err.Error())
 	}
 
-	var ngwords []*NGWord
-	if err := tx.SelectContext(ctx, &ngwords, "SELECT * FROM ng_words WHERE livestream_id = ?", livestreamID); err != nil {
+	// まだコミットされていないこのトランザクションの内容をグローバルキャッシュに
+	// 載せてしまうとロールバック時に不整合が残るので、ここではキャッシュを介さず
+	// 今追加した分を含むNGワードからその場限りの自動機を組み立てて使う。
+	var words []string
+	if err := tx.SelectContext(ctx, &words, "SELECT word FROM ng_words WHERE livestream_id = ?", livestreamID); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get NG words: "+err.Error())
 	}
+	ngAutomaton := newAhoCorasick(words)
 
-	// NGワードにヒットする過去の投稿も全削除する
-
-	// ライブコメント一覧取得
-	var deleteLivecommentIds []int64
-	query := `
-	SELECT l.id FROM livecomments l 
-	JOIN ng_words n 
-	ON n.livestream_id = l.livestream_id AND l.comment LIKE CONCAT('%', n.word, '%')
-	where l.livestream_id = ?
-	`
-	if err := tx.SelectContext(ctx, &deleteLivecommentIds, query, livestreamID); err != nil {
+	// NGワードにヒットする過去の投稿も全削除する。SQLのJOIN+LIKEではなく
+	// コメント本文を1回ずつ自動機に通すことでO(comments)に抑える。
+	var comments []LivecommentModel
+	if err := tx.SelectContext(ctx, &comments, "SELECT * FROM livecomments WHERE livestream_id = ?", livestreamID); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get livecomments: "+err.Error())
 	}
 
+	deleteLivecommentIds := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		if ngAutomaton.MatchString(comment.Comment) {
+			deleteLivecommentIds = append(deleteLivecommentIds, comment.ID)
+		}
+	}
+
 	if len(deleteLivecommentIds) > 0 {
 		deleteQuery, args, err := sqlx.In("DELETE FROM livecomments WHERE id in(?)", deleteLivecommentIds)
 		if err != nil {
@@ -472,12 +587,16 @@ func moderateHandler(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "failed to commit: "+err.Error())
 	}
 
+	// コミットが確定してから初めてキャッシュを破棄する。次の読み手がコミット
+	// 済みのNGワードから自動機を作り直す。
+	globalNGWordIndex.invalidate(int64(livestreamID))
+
 	return c.JSON(http.StatusCreated, map[string]interface{}{
 		"word_id": wordID,
 	})
 }
 
-func fillLivecommentResponse(ctx context.Context, tx *sqlx.Tx, livecommentModel LivecommentModel) (Livecomment, error) {
+func fillLivecommentResponse(ctx context.Context, tx *sqlx.Tx, livecommentModel LivecommentModel, viewerUserID int64) (Livecomment, error) {
 	fullUserModel := FullUserModel{}
 	var query = `
 	SELECT u.*, t.id as theme_id, t.dark_mode, i.icon_hash
@@ -519,13 +638,20 @@ func fillLivecommentResponse(ctx context.Context, tx *sqlx.Tx, livecommentModel
 		return Livecomment{}, err
 	}
 
+	reactions, myReactions, err := loadLivecommentReactions(ctx, tx, livecommentModel.ID, viewerUserID)
+	if err != nil {
+		return Livecomment{}, err
+	}
+
 	livecomment := Livecomment{
-		ID:         livecommentModel.ID,
-		User:       commentOwner,
-		Livestream: livestream,
-		Comment:    livecommentModel.Comment,
-		Tip:        livecommentModel.Tip,
-		CreatedAt:  livecommentModel.CreatedAt,
+		ID:          livecommentModel.ID,
+		User:        commentOwner,
+		Livestream:  livestream,
+		Comment:     livecommentModel.Comment,
+		Tip:         livecommentModel.Tip,
+		CreatedAt:   livecommentModel.CreatedAt,
+		Reactions:   reactions,
+		MyReactions: myReactions,
 	}
 
 	return livecomment, nil
@@ -568,7 +694,7 @@ func fillLivecommentReportResponse(ctx context.Context, tx *sqlx.Tx, reportModel
 	if err := tx.GetContext(ctx, &livecommentModel, "SELECT * FROM livecomments WHERE id = ?", reportModel.LivecommentID); err != nil {
 		return LivecommentReport{}, err
 	}
-	livecomment, err := fillLivecommentResponse(ctx, tx, livecommentModel)
+	livecomment, err := fillLivecommentResponse(ctx, tx, livecommentModel, reportModel.UserID)
 	if err != nil {
 		return LivecommentReport{}, err
 	}
@@ -581,3 +707,217 @@ func fillLivecommentReportResponse(ctx context.Context, tx *sqlx.Tx, reportModel
 	}
 	return report, nil
 }
+
+// fullUserModelsToUsersByID turns the rows of the users+themes+icons join
+// used throughout this file into a User lookup keyed by user ID, applying
+// the same default icon hash fallback as the single-row fill helpers.
+func fullUserModelsToUsersByID(fullUserModels []FullUserModel) map[int64]User {
+	usersByID := make(map[int64]User, len(fullUserModels))
+	for _, um := range fullUserModels {
+		if !um.IconHash.Valid {
+			um.IconHash.String = "d9f8294e9d895f81ce62e73dc7d5dff862a4fa40bd4e0fecf53f7526a8edcac0"
+		}
+		usersByID[um.ID] = User{
+			ID:          um.ID,
+			Name:        um.Name,
+			DisplayName: um.DisplayName,
+			Description: um.Description,
+			Theme: Theme{
+				ID:       um.ThemeId,
+				DarkMode: um.DarkMode,
+			},
+			IconHash: um.IconHash.String,
+		}
+	}
+	return usersByID
+}
+
+// fillLivecommentResponseBulk is the batched counterpart of
+// fillLivecommentResponse: it collects the distinct user and livestream IDs
+// across livecommentModels and fetches each with a single `WHERE id IN (?)`
+// query instead of re-fetching the user and livestream per livecomment.
+func fillLivecommentResponseBulk(ctx context.Context, tx *sqlx.Tx, livecommentModels []LivecommentModel, viewerUserID int64) ([]Livecomment, error) {
+	if len(livecommentModels) == 0 {
+		return []Livecomment{}, nil
+	}
+
+	userIDSet := map[int64]struct{}{}
+	livestreamIDSet := map[int64]struct{}{}
+	livecommentIDs := make([]int64, len(livecommentModels))
+	for i, m := range livecommentModels {
+		userIDSet[m.UserID] = struct{}{}
+		livestreamIDSet[m.LivestreamID] = struct{}{}
+		livecommentIDs[i] = m.ID
+	}
+	userIDs := make([]int64, 0, len(userIDSet))
+	for id := range userIDSet {
+		userIDs = append(userIDs, id)
+	}
+	livestreamIDs := make([]int64, 0, len(livestreamIDSet))
+	for id := range livestreamIDSet {
+		livestreamIDs = append(livestreamIDs, id)
+	}
+
+	userQuery, userArgs, err := sqlx.In(`
+	SELECT u.*, t.id as theme_id, t.dark_mode, i.icon_hash
+	FROM users u
+	LEFT JOIN themes t ON t.user_id = u.id
+	LEFT JOIN icons i ON i.user_id = u.id
+	WHERE u.id IN (?)
+	`, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	var fullUserModels []FullUserModel
+	if err := tx.SelectContext(ctx, &fullUserModels, userQuery, userArgs...); err != nil {
+		return nil, err
+	}
+	usersByID := fullUserModelsToUsersByID(fullUserModels)
+
+	livestreamQuery, livestreamArgs, err := sqlx.In("SELECT * FROM livestreams WHERE id IN (?)", livestreamIDs)
+	if err != nil {
+		return nil, err
+	}
+	var livestreamModels []LivestreamModel
+	if err := tx.SelectContext(ctx, &livestreamModels, livestreamQuery, livestreamArgs...); err != nil {
+		return nil, err
+	}
+	livestreamsByID := make(map[int64]Livestream, len(livestreamModels))
+	for _, lm := range livestreamModels {
+		livestream, err := fillLivestreamResponse(ctx, tx, lm)
+		if err != nil {
+			return nil, err
+		}
+		livestreamsByID[lm.ID] = livestream
+	}
+
+	reactionsByComment, myReactionsByComment, err := loadLivecommentReactionsBulk(ctx, tx, livecommentIDs, viewerUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	livecomments := make([]Livecomment, len(livecommentModels))
+	for i, m := range livecommentModels {
+		reactions := reactionsByComment[m.ID]
+		if reactions == nil {
+			reactions = map[string]int64{}
+		}
+		myReactions := myReactionsByComment[m.ID]
+		if myReactions == nil {
+			myReactions = []string{}
+		}
+
+		livecomments[i] = Livecomment{
+			ID:          m.ID,
+			User:        usersByID[m.UserID],
+			Livestream:  livestreamsByID[m.LivestreamID],
+			Comment:     m.Comment,
+			Tip:         m.Tip,
+			CreatedAt:   m.CreatedAt,
+			Reactions:   reactions,
+			MyReactions: myReactions,
+		}
+	}
+
+	return livecomments, nil
+}
+
+// fillLivecommentReportResponseBulk is the batched counterpart of
+// fillLivecommentReportResponse. It collects the distinct reporter IDs and
+// livecomment IDs across reportModels, resolves the livecomments (and, via
+// fillLivecommentResponseBulk, their users/livestreams) with one round of
+// `WHERE id IN (?)` queries, and stitches the results back together in Go.
+func fillLivecommentReportResponseBulk(ctx context.Context, tx *sqlx.Tx, reportModels []LivecommentReportModel) ([]LivecommentReport, error) {
+	if len(reportModels) == 0 {
+		return []LivecommentReport{}, nil
+	}
+
+	reporterIDSet := map[int64]struct{}{}
+	livecommentIDSet := map[int64]struct{}{}
+	for _, r := range reportModels {
+		reporterIDSet[r.UserID] = struct{}{}
+		livecommentIDSet[r.LivecommentID] = struct{}{}
+	}
+	reporterIDs := make([]int64, 0, len(reporterIDSet))
+	for id := range reporterIDSet {
+		reporterIDs = append(reporterIDs, id)
+	}
+	livecommentIDs := make([]int64, 0, len(livecommentIDSet))
+	for id := range livecommentIDSet {
+		livecommentIDs = append(livecommentIDs, id)
+	}
+
+	reporterQuery, reporterArgs, err := sqlx.In(`
+	SELECT u.*, t.id as theme_id, t.dark_mode, i.icon_hash
+	FROM users u
+	LEFT JOIN themes t ON t.user_id = u.id
+	LEFT JOIN icons i ON i.user_id = u.id
+	WHERE u.id IN (?)
+	`, reporterIDs)
+	if err != nil {
+		return nil, err
+	}
+	var fullUserModels []FullUserModel
+	if err := tx.SelectContext(ctx, &fullUserModels, reporterQuery, reporterArgs...); err != nil {
+		return nil, err
+	}
+	reportersByID := fullUserModelsToUsersByID(fullUserModels)
+
+	livecommentQuery, livecommentArgs, err := sqlx.In("SELECT * FROM livecomments WHERE id IN (?)", livecommentIDs)
+	if err != nil {
+		return nil, err
+	}
+	var livecommentModels []LivecommentModel
+	if err := tx.SelectContext(ctx, &livecommentModels, livecommentQuery, livecommentArgs...); err != nil {
+		return nil, err
+	}
+
+	// MyReactionsはレポートを出した本人から見た反応なので、commentごとの
+	// 集計とは別にreporterとのペアで1回のクエリを引く。
+	pairQuery, pairArgs, err := sqlx.In("SELECT livecomment_id, user_id, emoji FROM livecomment_reactions WHERE livecomment_id IN (?) AND user_id IN (?)", livecommentIDs, reporterIDs)
+	if err != nil {
+		return nil, err
+	}
+	type reactionPairRow struct {
+		LivecommentID int64  `db:"livecomment_id"`
+		UserID        int64  `db:"user_id"`
+		Emoji         string `db:"emoji"`
+	}
+	var pairRows []reactionPairRow
+	if err := tx.SelectContext(ctx, &pairRows, pairQuery, pairArgs...); err != nil {
+		return nil, err
+	}
+	myReactionsByPair := map[[2]int64][]string{}
+	for _, r := range pairRows {
+		key := [2]int64{r.LivecommentID, r.UserID}
+		myReactionsByPair[key] = append(myReactionsByPair[key], r.Emoji)
+	}
+
+	livecomments, err := fillLivecommentResponseBulk(ctx, tx, livecommentModels, 0)
+	if err != nil {
+		return nil, err
+	}
+	livecommentsByID := make(map[int64]Livecomment, len(livecomments))
+	for _, lc := range livecomments {
+		livecommentsByID[lc.ID] = lc
+	}
+
+	reports := make([]LivecommentReport, len(reportModels))
+	for i, r := range reportModels {
+		livecomment := livecommentsByID[r.LivecommentID]
+		myReactions := myReactionsByPair[[2]int64{r.LivecommentID, r.UserID}]
+		if myReactions == nil {
+			myReactions = []string{}
+		}
+		livecomment.MyReactions = myReactions
+
+		reports[i] = LivecommentReport{
+			ID:          r.ID,
+			Reporter:    reportersByID[r.UserID],
+			Livecomment: livecomment,
+			CreatedAt:   r.CreatedAt,
+		}
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+)
+
+type PostLivecommentReactionRequest struct {
+	Emoji string `json:"emoji"`
+}
+
+type LivecommentReactionModel struct {
+	ID            int64  `db:"id"`
+	UserID        int64  `db:"user_id"`
+	LivecommentID int64  `db:"livecomment_id"`
+	Emoji         string `db:"emoji"`
+	CreatedAt     int64  `db:"created_at"`
+}
+
+// addLivecommentReactionHandler adds the requesting user's reaction to a
+// livecomment. Mounted at
+// POST /api/livestream/:livestream_id/livecomment/:livecomment_id/reaction.
+func addLivecommentReactionHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	defer c.Request().Body.Close()
+
+	if err := verifyUserSession(c); err != nil {
+		return err
+	}
+
+	livecommentID, err := strconv.Atoi(c.Param("livecomment_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "livecomment_id in path must be integer")
+	}
+
+	// error already checked
+	sess, _ := session.Get(defaultSessionIDKey, c)
+	// existence already checked
+	userID := sess.Values[defaultUserIDKey].(int64)
+
+	var req *PostLivecommentReactionRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "failed to decode the request body as json")
+	}
+	if req.Emoji == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "emoji must not be empty")
+	}
+
+	tx, err := dbConn.BeginTxx(ctx, nil)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to begin transaction: "+err.Error())
+	}
+	defer tx.Rollback()
+
+	var livecommentModel LivecommentModel
+	if err := tx.GetContext(ctx, &livecommentModel, "SELECT * FROM livecomments WHERE id = ?", livecommentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, "livecomment not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get livecomment: "+err.Error())
+	}
+
+	if _, err := tx.ExecContext(ctx, "INSERT IGNORE INTO livecomment_reactions (user_id, livecomment_id, emoji, created_at) VALUES (?, ?, ?, ?)", userID, livecommentID, req.Emoji, time.Now().Unix()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to insert livecomment reaction: "+err.Error())
+	}
+
+	livecomment, err := fillLivecommentResponse(ctx, tx, livecommentModel, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to fill livecomment: "+err.Error())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to commit: "+err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, livecomment)
+}
+
+// removeLivecommentReactionHandler removes the requesting user's reaction
+// (identified by the `emoji` query parameter) from a livecomment. Mounted at
+// DELETE /api/livestream/:livestream_id/livecomment/:livecomment_id/reaction.
+func removeLivecommentReactionHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if err := verifyUserSession(c); err != nil {
+		return err
+	}
+
+	livecommentID, err := strconv.Atoi(c.Param("livecomment_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "livecomment_id in path must be integer")
+	}
+
+	emoji := c.QueryParam("emoji")
+	if emoji == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "emoji query parameter must not be empty")
+	}
+
+	// error already checked
+	sess, _ := session.Get(defaultSessionIDKey, c)
+	// existence already checked
+	userID := sess.Values[defaultUserIDKey].(int64)
+
+	tx, err := dbConn.BeginTxx(ctx, nil)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to begin transaction: "+err.Error())
+	}
+	defer tx.Rollback()
+
+	var livecommentModel LivecommentModel
+	if err := tx.GetContext(ctx, &livecommentModel, "SELECT * FROM livecomments WHERE id = ?", livecommentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, "livecomment not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get livecomment: "+err.Error())
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM livecomment_reactions WHERE user_id = ? AND livecomment_id = ? AND emoji = ?", userID, livecommentID, emoji); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete livecomment reaction: "+err.Error())
+	}
+
+	livecomment, err := fillLivecommentResponse(ctx, tx, livecommentModel, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to fill livecomment: "+err.Error())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to commit: "+err.Error())
+	}
+
+	return c.JSON(http.StatusOK, livecomment)
+}
+
+// loadLivecommentReactions aggregates reaction counts for a single
+// livecomment along with which of those emoji viewerUserID has reacted with.
+func loadLivecommentReactions(ctx context.Context, tx *sqlx.Tx, livecommentID, viewerUserID int64) (map[string]int64, []string, error) {
+	type reactionCount struct {
+		Emoji string `db:"emoji"`
+		Count int64  `db:"count"`
+	}
+	var counts []reactionCount
+	if err := tx.SelectContext(ctx, &counts, "SELECT emoji, COUNT(*) AS count FROM livecomment_reactions WHERE livecomment_id = ? GROUP BY emoji", livecommentID); err != nil {
+		return nil, nil, err
+	}
+
+	reactions := make(map[string]int64, len(counts))
+	for _, r := range counts {
+		reactions[r.Emoji] = r.Count
+	}
+
+	myReactions := []string{}
+	if err := tx.SelectContext(ctx, &myReactions, "SELECT emoji FROM livecomment_reactions WHERE livecomment_id = ? AND user_id = ?", livecommentID, viewerUserID); err != nil {
+		return nil, nil, err
+	}
+
+	return reactions, myReactions, nil
+}
+
+// loadLivecommentReactionsBulk is the batched counterpart of
+// loadLivecommentReactions, used by getLivecommentsHandler to aggregate
+// reactions for a whole page of comments in two queries instead of per-row.
+func loadLivecommentReactionsBulk(ctx context.Context, tx *sqlx.Tx, livecommentIDs []int64, viewerUserID int64) (map[int64]map[string]int64, map[int64][]string, error) {
+	reactions := make(map[int64]map[string]int64, len(livecommentIDs))
+	myReactions := make(map[int64][]string, len(livecommentIDs))
+	if len(livecommentIDs) == 0 {
+		return reactions, myReactions, nil
+	}
+
+	type reactionCountRow struct {
+		LivecommentID int64  `db:"livecomment_id"`
+		Emoji         string `db:"emoji"`
+		Count         int64  `db:"count"`
+	}
+	query, args, err := sqlx.In("SELECT livecomment_id, emoji, COUNT(*) AS count FROM livecomment_reactions WHERE livecomment_id IN (?) GROUP BY livecomment_id, emoji", livecommentIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	var counts []reactionCountRow
+	if err := tx.SelectContext(ctx, &counts, query, args...); err != nil {
+		return nil, nil, err
+	}
+	for _, r := range counts {
+		if reactions[r.LivecommentID] == nil {
+			reactions[r.LivecommentID] = map[string]int64{}
+		}
+		reactions[r.LivecommentID][r.Emoji] = r.Count
+	}
+
+	// viewerUserID == 0 means the caller doesn't need per-viewer MyReactions
+	// (e.g. fillLivecommentReportResponseBulk, which stitches those in itself
+	// per-reporter), so skip the query entirely instead of running it and
+	// throwing the result away.
+	if viewerUserID == 0 {
+		return reactions, myReactions, nil
+	}
+
+	type myReactionRow struct {
+		LivecommentID int64  `db:"livecomment_id"`
+		Emoji         string `db:"emoji"`
+	}
+	query, args, err = sqlx.In("SELECT livecomment_id, emoji FROM livecomment_reactions WHERE livecomment_id IN (?) AND user_id = ?", livecommentIDs, viewerUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var myRows []myReactionRow
+	if err := tx.SelectContext(ctx, &myRows, query, args...); err != nil {
+		return nil, nil, err
+	}
+	for _, r := range myRows {
+		myReactions[r.LivecommentID] = append(myReactions[r.LivecommentID], r.Emoji)
+	}
+
+	return reactions, myReactions, nil
+}
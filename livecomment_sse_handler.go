@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	commentHubSubscriberBuffer = 16
+	commentHubHeartbeatPeriod  = 15 * time.Second
+)
+
+// commentHub fans a livestream's new Livecomments out to every subscriber
+// currently connected to the SSE endpoint for that livestream.
+type commentHub struct {
+	mu          sync.Mutex
+	subscribers map[chan Livecomment]struct{}
+}
+
+func newCommentHub() *commentHub {
+	return &commentHub{subscribers: map[chan Livecomment]struct{}{}}
+}
+
+func (h *commentHub) subscribe() chan Livecomment {
+	ch := make(chan Livecomment, commentHubSubscriberBuffer)
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+	return ch
+}
+
+func (h *commentHub) unsubscribe(ch chan Livecomment) {
+	h.mu.Lock()
+	delete(h.subscribers, ch)
+	h.mu.Unlock()
+	close(ch)
+}
+
+func (h *commentHub) publish(livecomment Livecomment) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- livecomment:
+		default:
+			// subscriber isn't keeping up; drop the oldest queued comment
+			// rather than blocking the publisher (the hot path commit).
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- livecomment:
+			default:
+			}
+		}
+	}
+}
+
+// commentHubRegistry holds one commentHub per livestream, created lazily.
+type commentHubRegistry struct {
+	mu   sync.Mutex
+	hubs map[int64]*commentHub
+}
+
+var globalCommentHubs = &commentHubRegistry{hubs: map[int64]*commentHub{}}
+
+func (r *commentHubRegistry) get(livestreamID int64) *commentHub {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	hub, ok := r.hubs[livestreamID]
+	if !ok {
+		hub = newCommentHub()
+		r.hubs[livestreamID] = hub
+	}
+	return hub
+}
+
+// publishLivecomment is called by postLivecommentHandler once the comment is
+// durably committed, so subscribers never see a comment that could still be
+// rolled back.
+func publishLivecomment(livecomment Livecomment) {
+	globalCommentHubs.get(livecomment.Livestream.ID).publish(livecomment)
+}
+
+// getLivecommentStreamHandler upgrades to Server-Sent Events and pushes each
+// new Livecomment for the livestream as it arrives, so viewers no longer
+// need to poll getLivecommentsHandler. Mounted at
+// GET /api/livestream/:livestream_id/livecomment/stream.
+func getLivecommentStreamHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if err := verifyUserSession(c); err != nil {
+		return err
+	}
+
+	livestreamID, err := strconv.Atoi(c.Param("livestream_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "livestream_id in path must be integer")
+	}
+
+	var sinceUnix int64
+	hasSince := false
+	if since := c.QueryParam("since"); since != "" {
+		sinceUnix, err = strconv.ParseInt(since, 10, 64)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "since query parameter must be a unix timestamp")
+		}
+		hasSince = true
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+
+	// DBからのリプレイより前にhubを購読しておく。そうしないとリプレイの
+	// SELECTとここでのsubscribeの間に投稿されたコメントが、リプレイにも
+	// 購読先のチャンネルにも載らずに消えてしまう(多少の重複配信は許容する)。
+	hub := globalCommentHubs.get(int64(livestreamID))
+	sub := hub.subscribe()
+	defer hub.unsubscribe(sub)
+
+	// since検証とDBからのリプレイはここまでに終わらせる。一度でも書き込むと
+	// 200がコミットされてしまい、それ以降のエラーをクライアントに返せない。
+	if hasSince {
+		if err := flushRecentLivecomments(ctx, c, livestreamID, sinceUnix); err != nil {
+			return err
+		}
+	}
+	if !res.Committed {
+		res.WriteHeader(http.StatusOK)
+	}
+
+	heartbeat := time.NewTicker(commentHubHeartbeatPeriod)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case livecomment := <-sub:
+			if err := writeLivecommentEvent(c, livecomment); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+func writeLivecommentEvent(c echo.Context, livecomment Livecomment) error {
+	payload, err := json.Marshal(livecomment)
+	if err != nil {
+		return err
+	}
+	res := c.Response()
+	if _, err := fmt.Fprintf(res, "event: livecomment\ndata: %s\n\n", payload); err != nil {
+		return err
+	}
+	res.Flush()
+	return nil
+}
+
+// flushRecentLivecomments writes every comment posted since sinceUnix before
+// the handler switches the connection over to live push, so a client
+// reconnecting with ?since=<unix> doesn't miss anything published in
+// between.
+func flushRecentLivecomments(ctx context.Context, c echo.Context, livestreamID int, sinceUnix int64) error {
+	tx, err := dbConn.BeginTxx(ctx, nil)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to begin transaction: "+err.Error())
+	}
+	defer tx.Rollback()
+
+	livestreamModel := LivestreamModel{}
+	if err := tx.GetContext(ctx, &livestreamModel, "SELECT * FROM livestreams WHERE id = ?", livestreamID); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get livestream: "+err.Error())
+	}
+	livestream, err := fillLivestreamResponse(ctx, tx, livestreamModel)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	SELECT
+	l.*,
+	u.name, u.display_name, u.description, t.id as theme_id, i.icon_hash
+	FROM livecomments l
+	JOIN users u ON u.id = l.user_id
+	LEFT JOIN themes t ON t.user_id = u.id
+	LEFT JOIN icons i ON i.user_id = u.id
+	WHERE l.livestream_id = ? AND l.created_at >= ?
+	ORDER BY l.created_at ASC
+	`
+	var livecommentModels []LivecommentModelWithUser
+	if err := tx.SelectContext(ctx, &livecommentModels, query, livestreamID, sinceUnix); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get livecomments: "+err.Error())
+	}
+
+	for _, m := range livecommentModels {
+		if !m.UserIconHash.Valid {
+			m.UserIconHash.String = "d9f8294e9d895f81ce62e73dc7d5dff862a4fa40bd4e0fecf53f7526a8edcac0"
+		}
+		livecomment := Livecomment{
+			ID: m.ID,
+			User: User{
+				ID:          m.UserID,
+				Name:        m.UserName,
+				DisplayName: m.UserDisplayName,
+				Description: m.UserDescription,
+				Theme: Theme{
+					ID:       m.UserThemeId,
+					DarkMode: m.UserDarkMode,
+				},
+				IconHash: m.UserIconHash.String,
+			},
+			Livestream:  livestream,
+			Comment:     m.Comment,
+			Tip:         m.Tip,
+			CreatedAt:   m.CreatedAt,
+			Reactions:   map[string]int64{},
+			MyReactions: []string{},
+		}
+		if err := writeLivecommentEvent(c, livecomment); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// acNode is a single state in the Aho–Corasick automaton. Transitions are
+// keyed by raw byte, which is sufficient because NG words are also compared
+// byte-wise (no need to decode UTF-8 runes to detect a substring hit).
+type acNode struct {
+	children map[byte]*acNode
+	fail     *acNode
+	output   bool // true if this state (or one reachable via its fail chain) completes an NG word
+}
+
+func newACNode() *acNode {
+	return &acNode{children: map[byte]*acNode{}}
+}
+
+// ahoCorasick is a compiled multi-pattern matcher over a fixed set of NG
+// words. It reports only whether any pattern occurs in a given text; it does
+// not need to report which one, since moderateHandler and
+// postLivecommentHandler only ever branch on "is this spam".
+type ahoCorasick struct {
+	root *acNode
+}
+
+// newAhoCorasick builds the trie and its fail links (goto + suffix links),
+// propagating `output` along the fail chain so a match of a shorter
+// overlapping pattern (e.g. "ab" inside "abc") is still reported.
+func newAhoCorasick(words []string) *ahoCorasick {
+	root := newACNode()
+
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		node := root
+		for i := 0; i < len(word); i++ {
+			b := word[i]
+			next, ok := node.children[b]
+			if !ok {
+				next = newACNode()
+				node.children[b] = next
+			}
+			node = next
+		}
+		node.output = true
+	}
+
+	// BFS to compute fail links: root's children fail to root, and every
+	// other node's fail link is found by following its parent's fail link.
+	queue := make([]*acNode, 0, len(root.children))
+	for _, child := range root.children {
+		child.fail = root
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for b, child := range node.children {
+			queue = append(queue, child)
+
+			fail := node.fail
+			for fail != nil {
+				if next, ok := fail.children[b]; ok {
+					child.fail = next
+					break
+				}
+				fail = fail.fail
+			}
+			if child.fail == nil {
+				child.fail = root
+			}
+			if child.fail.output {
+				child.output = true
+			}
+		}
+	}
+
+	return &ahoCorasick{root: root}
+}
+
+// MatchString reports whether any NG word occurs in text, walking text's
+// bytes through the automaton and following goto/fail transitions. It
+// returns on the first hit, so it's at worst O(len(text)).
+func (ac *ahoCorasick) MatchString(text string) bool {
+	node := ac.root
+	for i := 0; i < len(text); i++ {
+		b := text[i]
+		for node != ac.root {
+			if _, ok := node.children[b]; ok {
+				break
+			}
+			node = node.fail
+		}
+		if next, ok := node.children[b]; ok {
+			node = next
+		}
+		if node.output {
+			return true
+		}
+	}
+	return false
+}
+
+// ngWordIndex caches one compiled automaton per livestream so that
+// postLivecommentHandler doesn't have to re-scan NG words with SQL LIKE on
+// every request. moderateHandler does not read from it (it builds its own
+// automaton inside its transaction); it only invalidates the cached entry
+// after committing a new NG word for that livestream.
+type ngWordIndex struct {
+	mu        sync.RWMutex
+	automaton map[int64]*ahoCorasick
+}
+
+var globalNGWordIndex = &ngWordIndex{
+	automaton: map[int64]*ahoCorasick{},
+}
+
+// invalidate drops the cached automaton for a livestream, forcing the next
+// lookup to rebuild it from the current ng_words rows.
+func (idx *ngWordIndex) invalidate(livestreamID int64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	delete(idx.automaton, livestreamID)
+}
+
+// getOrBuild returns the cached automaton for livestreamID, building and
+// caching one from the database if it isn't present yet.
+func (idx *ngWordIndex) getOrBuild(ctx context.Context, tx *sqlx.Tx, livestreamID int64) (*ahoCorasick, error) {
+	idx.mu.RLock()
+	ac, ok := idx.automaton[livestreamID]
+	idx.mu.RUnlock()
+	if ok {
+		return ac, nil
+	}
+
+	var words []string
+	if err := tx.SelectContext(ctx, &words, "SELECT word FROM ng_words WHERE livestream_id = ?", livestreamID); err != nil {
+		return nil, err
+	}
+	ac = newAhoCorasick(words)
+
+	idx.mu.Lock()
+	idx.automaton[livestreamID] = ac
+	idx.mu.Unlock()
+
+	return ac, nil
+}
+
+// hit reports whether comment matches any NG word registered for
+// livestreamID. It uses the cached automaton whenever it's available; if the
+// automaton can't be built (e.g. a transient error fetching ng_words), it
+// falls back to a single round-trip SQL LIKE check so a moderation-index
+// hiccup never blocks normal comment posting.
+func (idx *ngWordIndex) hit(ctx context.Context, tx *sqlx.Tx, userID, livestreamID int64, comment string) (bool, error) {
+	ac, err := idx.getOrBuild(ctx, tx, livestreamID)
+	if err == nil {
+		return ac.MatchString(comment), nil
+	}
+
+	var matched int
+	fallbackErr := tx.GetContext(ctx, &matched,
+		"SELECT 1 FROM ng_words WHERE user_id = ? AND livestream_id = ? AND ? LIKE CONCAT('%', word, '%') LIMIT 1",
+		userID, livestreamID, comment)
+	if errors.Is(fallbackErr, sql.ErrNoRows) {
+		return false, nil
+	}
+	if fallbackErr != nil {
+		return false, fallbackErr
+	}
+	return matched == 1, nil
+}
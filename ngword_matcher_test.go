@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestAhoCorasickOverlappingPatterns(t *testing.T) {
+	ac := newAhoCorasick([]string{"ab", "abc"})
+
+	if !ac.MatchString("xxabxx") {
+		t.Error("expected match on shorter overlapping pattern \"ab\"")
+	}
+	if !ac.MatchString("xxabcxx") {
+		t.Error("expected match on longer overlapping pattern \"abc\"")
+	}
+	if ac.MatchString("xxacxx") {
+		t.Error("did not expect a match when neither pattern occurs")
+	}
+}
+
+func TestAhoCorasickEmptyNGWordList(t *testing.T) {
+	ac := newAhoCorasick(nil)
+
+	if ac.MatchString("") {
+		t.Error("empty automaton must not match the empty string")
+	}
+	if ac.MatchString("anything goes here") {
+		t.Error("empty automaton must not match any text")
+	}
+}
+
+func TestAhoCorasickUTF8MultibytePatterns(t *testing.T) {
+	ac := newAhoCorasick([]string{"ばか", "\U0001F4A9"})
+
+	if !ac.MatchString("お前はばかだ") {
+		t.Error("expected match on multibyte NG word \"ばか\"")
+	}
+	if !ac.MatchString("spam" + "\U0001F4A9" + "spam") {
+		t.Error("expected match on multibyte emoji NG word")
+	}
+	if ac.MatchString("まったく問題ないコメントです") {
+		t.Error("did not expect a match on unrelated multibyte text")
+	}
+}